@@ -0,0 +1,250 @@
+// Package collector runs smartctl scrapes on a background schedule and
+// caches the parsed results, so that serving an HTTP scrape never has to
+// wait on (or trigger) a smartctl invocation.
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tidwall/gjson"
+)
+
+// Logger is the subset of the exporter's logger the scheduler needs. It lets
+// failed smartctl invocations be reported without this package depending on
+// package main's logger type.
+type Logger interface {
+	Error(format string, v ...interface{})
+}
+
+// nopLogger discards everything; used when Config.Logger is left unset.
+type nopLogger struct{}
+
+func (nopLogger) Error(string, ...interface{}) {}
+
+// Config controls how the scheduler discovers devices and refreshes their
+// smartctl output.
+type Config struct {
+	Runner         SmartctlRunner
+	ScanInterval   time.Duration
+	ScrapeInterval time.Duration
+	CacheTTL       time.Duration
+	MaxConcurrency int
+	Logger         Logger
+}
+
+// cacheEntry is the most recently parsed smartctl output for a single device.
+type cacheEntry struct {
+	json     gjson.Result
+	scrapeAt time.Time
+}
+
+// Scheduler discovers devices via `smartctl --scan` and periodically
+// refreshes `smartctl -a` output for each one in the background. Devices()
+// and Get() only read the cached results, so they never shell out to
+// smartctl themselves.
+type Scheduler struct {
+	cfg Config
+
+	mu      sync.RWMutex
+	cache   map[string]cacheEntry
+	devices []string
+
+	scrapeDuration *prometheus.HistogramVec
+	scrapeErrors   *prometheus.CounterVec
+	lastSuccess    *prometheus.GaugeVec
+}
+
+// NewScheduler is the scheduler constructor.
+func NewScheduler(cfg Config) *Scheduler {
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = 1
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = nopLogger{}
+	}
+	if cfg.Runner == nil {
+		cfg.Runner = ExecRunner{Path: "smartctl"}
+	}
+	return &Scheduler{
+		cfg:   cfg,
+		cache: make(map[string]cacheEntry),
+		scrapeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "smartctl_collect_duration_seconds",
+			Help: "Time spent running smartctl for a device",
+		}, []string{"device"}),
+		scrapeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "smartctl_collect_errors_total",
+			Help: "Number of failed smartctl invocations for a device",
+		}, []string{"device"}),
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "smartctl_collect_last_success_timestamp_seconds",
+			Help: "Unix timestamp of the last successful smartctl scrape for a device",
+		}, []string{"device"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (s *Scheduler) Describe(ch chan<- *prometheus.Desc) {
+	s.scrapeDuration.Describe(ch)
+	s.scrapeErrors.Describe(ch)
+	s.lastSuccess.Describe(ch)
+}
+
+// Collect implements prometheus.Collector, reporting the scheduler's own
+// scrape health metrics (not per-device SMART data).
+func (s *Scheduler) Collect(ch chan<- prometheus.Metric) {
+	s.scrapeDuration.Collect(ch)
+	s.scrapeErrors.Collect(ch)
+	s.lastSuccess.Collect(ch)
+}
+
+// Run starts the discovery and scrape loops. It blocks until ctx is
+// cancelled, so callers should invoke it in its own goroutine. The initial
+// device discovery runs before the first scrape, so that scrape has a
+// device list to work from instead of waiting a full ScrapeInterval.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.discover()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		s.discoverLoop(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		s.scrapeLoop(ctx)
+	}()
+	wg.Wait()
+}
+
+// Devices returns the devices found by the most recent scan.
+func (s *Scheduler) Devices() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	devices := make([]string, len(s.devices))
+	copy(devices, s.devices)
+	return devices
+}
+
+// Get returns the cached smartctl JSON for device, and whether it is both
+// present and younger than the configured cache TTL.
+func (s *Scheduler) Get(device string) (gjson.Result, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.cache[device]
+	if !ok || time.Since(entry.scrapeAt) > s.cfg.CacheTTL {
+		return gjson.Result{}, false
+	}
+	return entry.json, true
+}
+
+// discoverLoop re-runs discovery on every tick. The initial discovery is
+// performed by Run before this loop starts.
+func (s *Scheduler) discoverLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.ScanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.discover()
+		}
+	}
+}
+
+// singleDeviceRunner is implemented by runners that only ever produce one
+// device's JSON document (e.g. StdinRunner), which isn't a `--scan` listing
+// and can't be discovered by running one.
+type singleDeviceRunner interface {
+	singleDevice() (string, error)
+}
+
+func (s *Scheduler) discover() {
+	if r, ok := s.cfg.Runner.(singleDeviceRunner); ok {
+		device, err := r.singleDevice()
+		if err != nil {
+			s.cfg.Logger.Error("reading stdin smartctl device: %s", err)
+			return
+		}
+		s.mu.Lock()
+		s.devices = []string{device}
+		s.mu.Unlock()
+		return
+	}
+	result, err := s.cfg.Runner.Run("--scan", "--json")
+	if err != nil {
+		s.cfg.Logger.Error("smartctl --scan --json failed: %s", err)
+		return
+	}
+	var devices []string
+	for _, device := range result.Get("devices").Array() {
+		name := device.Get("name").String()
+		if name != "" {
+			devices = append(devices, name)
+		}
+	}
+	s.mu.Lock()
+	s.devices = devices
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) scrapeLoop(ctx context.Context) {
+	s.scrapeAll(ctx)
+	ticker := time.NewTicker(s.cfg.ScrapeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.scrapeAll(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) scrapeAll(ctx context.Context) {
+	sem := make(chan struct{}, s.cfg.MaxConcurrency)
+	var wg sync.WaitGroup
+	for _, device := range s.Devices() {
+		device := device
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.Scrape(device)
+		}()
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		default:
+		}
+	}
+	wg.Wait()
+}
+
+// Scrape runs smartctl for device synchronously. Every call records its
+// duration; a failure increments the error counter, and a success updates
+// the cache and the last-success timestamp. Callers normally read cached data through Get;
+// Scrape is exported so a caller can also force a fresh, synchronous read
+// for a device when the cache is cold.
+func (s *Scheduler) Scrape(device string) (gjson.Result, error) {
+	start := time.Now()
+	result, err := s.cfg.Runner.Run("-a", "--json", device)
+	s.scrapeDuration.WithLabelValues(device).Observe(time.Since(start).Seconds())
+	if err != nil {
+		s.scrapeErrors.WithLabelValues(device).Inc()
+		s.cfg.Logger.Error("smartctl -a --json %s failed: %s", device, err)
+		return gjson.Result{}, err
+	}
+	s.mu.Lock()
+	s.cache[device] = cacheEntry{json: result, scrapeAt: time.Now()}
+	s.mu.Unlock()
+	s.lastSuccess.WithLabelValues(device).SetToCurrentTime()
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+func newTestScheduler(runner SmartctlRunner, cacheTTL time.Duration) *Scheduler {
+	return NewScheduler(Config{
+		Runner:         runner,
+		CacheTTL:       cacheTTL,
+		MaxConcurrency: 2,
+	})
+}
+
+func TestSchedulerDiscoverAndScrape(t *testing.T) {
+	s := newTestScheduler(FileRunner{Dir: "testdata/fake"}, time.Minute)
+
+	s.discover()
+	devices := s.Devices()
+	if len(devices) != 1 || devices[0] != "/dev/sda" {
+		t.Fatalf("Devices() = %v, want [/dev/sda]", devices)
+	}
+
+	if _, ok := s.Get("/dev/sda"); ok {
+		t.Fatal("Get() ok = true before any scrape, want false")
+	}
+
+	result, err := s.Scrape("/dev/sda")
+	if err != nil {
+		t.Fatalf("Scrape() error = %s", err)
+	}
+	if got, want := result.Get("serial_number").String(), "FXCOLLECTOR1"; got != want {
+		t.Errorf("Scrape() serial_number = %q, want %q", got, want)
+	}
+
+	cached, ok := s.Get("/dev/sda")
+	if !ok {
+		t.Fatal("Get() ok = false after a successful scrape, want true")
+	}
+	if cached.Get("serial_number").String() != "FXCOLLECTOR1" {
+		t.Errorf("Get() returned unexpected cached document: %s", cached.Raw)
+	}
+}
+
+func TestSchedulerGetExpiresAfterTTL(t *testing.T) {
+	s := newTestScheduler(FileRunner{Dir: "testdata/fake"}, time.Millisecond)
+
+	if _, err := s.Scrape("/dev/sda"); err != nil {
+		t.Fatalf("Scrape() error = %s", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := s.Get("/dev/sda"); ok {
+		t.Fatal("Get() ok = true after the cache TTL elapsed, want false")
+	}
+}
+
+// TestSchedulerStdinDiscovery drives a StdinRunner through the scheduler's
+// real discover()/Scrape()/Get() path, rather than testing StdinRunner in
+// isolation: discover() must seed the device list from the stdin document's
+// own device.name instead of expecting a --scan listing that stdin mode
+// never produces.
+func TestSchedulerStdinDiscovery(t *testing.T) {
+	read, write, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %s", err)
+	}
+	if _, err := write.WriteString(`{"device":{"name":"/dev/sda"},"serial_number":"FXSTDIN"}`); err != nil {
+		t.Fatalf("writing to pipe: %s", err)
+	}
+	write.Close()
+
+	origStdin := os.Stdin
+	os.Stdin = read
+	defer func() { os.Stdin = origStdin }()
+
+	s := newTestScheduler(&StdinRunner{}, time.Minute)
+	s.discover()
+
+	devices := s.Devices()
+	if len(devices) != 1 || devices[0] != "/dev/sda" {
+		t.Fatalf("Devices() = %v, want [/dev/sda]", devices)
+	}
+
+	if _, err := s.Scrape("/dev/sda"); err != nil {
+		t.Fatalf("Scrape() error = %s", err)
+	}
+	cached, ok := s.Get("/dev/sda")
+	if !ok {
+		t.Fatal("Get() ok = false after a successful scrape, want true")
+	}
+	if got, want := cached.Get("serial_number").String(), "FXSTDIN"; got != want {
+		t.Errorf("Get() serial_number = %q, want %q", got, want)
+	}
+}
+
+// erroringRunner is a SmartctlRunner stub that always fails, for testing the
+// scheduler's error handling without shelling out.
+type erroringRunner struct {
+	err error
+}
+
+func (r erroringRunner) Run(args ...string) (gjson.Result, error) {
+	return gjson.Result{}, r.err
+}
+
+func TestSchedulerScrapeError(t *testing.T) {
+	s := newTestScheduler(erroringRunner{errors.New("boom")}, time.Minute)
+	if _, err := s.Scrape("/dev/sda"); err == nil {
+		t.Fatal("Scrape() error = nil, want the runner's error")
+	}
+	if _, ok := s.Get("/dev/sda"); ok {
+		t.Fatal("Get() ok = true after a failed scrape, want false")
+	}
+}
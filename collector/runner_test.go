@@ -0,0 +1,91 @@
+package collector
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExecRunnerSuccess(t *testing.T) {
+	result, err := ExecRunner{Path: "echo"}.Run(`{"ok":true}`)
+	if err != nil {
+		t.Fatalf("Run() error = %s", err)
+	}
+	if !result.Get("ok").Bool() {
+		t.Errorf("Run() result = %s, want ok=true", result.Raw)
+	}
+}
+
+func TestExecRunnerEmptyOutput(t *testing.T) {
+	runner := ExecRunner{Path: "true"}
+	if _, err := runner.Run(); err == nil {
+		t.Fatal("Run() error = nil, want an error for empty output")
+	}
+}
+
+func TestExecRunnerInvalidJSON(t *testing.T) {
+	runner := ExecRunner{Path: "echo"}
+	if _, err := runner.Run("not-json"); err == nil {
+		t.Fatal("Run() error = nil, want an error for non-JSON output")
+	}
+}
+
+func TestFileRunnerScanAndDevice(t *testing.T) {
+	runner := FileRunner{Dir: "testdata/fake"}
+
+	scan, err := runner.Run("--scan", "--json")
+	if err != nil {
+		t.Fatalf("Run(scan) error = %s", err)
+	}
+	if got, want := scan.Get("devices.0.name").String(), "/dev/sda"; got != want {
+		t.Errorf("scan device name = %q, want %q", got, want)
+	}
+
+	device, err := runner.Run("-a", "--json", "/dev/sda")
+	if err != nil {
+		t.Fatalf("Run(device) error = %s", err)
+	}
+	if got, want := device.Get("serial_number").String(), "FXCOLLECTOR1"; got != want {
+		t.Errorf("device serial = %q, want %q", got, want)
+	}
+}
+
+func TestFileRunnerMissingFile(t *testing.T) {
+	runner := FileRunner{Dir: "testdata/fake"}
+	if _, err := runner.Run("-a", "--json", "/dev/does-not-exist"); err == nil {
+		t.Fatal("Run() error = nil, want an error for a missing fixture file")
+	}
+}
+
+func TestStdinRunner(t *testing.T) {
+	read, write, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %s", err)
+	}
+	if _, err := write.WriteString(`{"serial_number":"FXSTDIN"}`); err != nil {
+		t.Fatalf("writing to pipe: %s", err)
+	}
+	write.Close()
+
+	origStdin := os.Stdin
+	os.Stdin = read
+	defer func() { os.Stdin = origStdin }()
+
+	runner := &StdinRunner{}
+	first, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run() error = %s", err)
+	}
+	if got, want := first.Get("serial_number").String(), "FXSTDIN"; got != want {
+		t.Errorf("serial_number = %q, want %q", got, want)
+	}
+
+	// A second call must not try to read stdin again (it's already drained);
+	// it should return the same cached document.
+	second, err := runner.Run()
+	if err != nil {
+		t.Fatalf("second Run() error = %s", err)
+	}
+	if second.Get("serial_number").String() != first.Get("serial_number").String() {
+		t.Errorf("second Run() returned a different document than the first")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/jamesnewby/smartctl_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SMARTMonCollector is the top-level prometheus.Collector for this exporter.
+// It is backed by a collector.Scheduler, so a scrape normally just reads
+// whatever the background scheduler has already cached for each device.
+type SMARTMonCollector struct {
+	scheduler *collector.Scheduler
+}
+
+// NewSMARTMonCollector is the SMARTMonCollector constructor.
+func NewSMARTMonCollector(scheduler *collector.Scheduler) *SMARTMonCollector {
+	return &SMARTMonCollector{scheduler: scheduler}
+}
+
+// Describe implements prometheus.Collector. Per-device metrics are all
+// built as const metrics while scraping, so there is nothing to describe
+// ahead of time.
+func (c *SMARTMonCollector) Describe(ch chan<- *prometheus.Desc) {
+}
+
+// Collect implements prometheus.Collector. It only ever reads each known
+// device's smartctl JSON from the scheduler's cache; a device that hasn't
+// been scraped yet (or is persistently failing to scrape) is skipped rather
+// than triggering a synchronous smartctl invocation on this goroutine. A
+// fast first scrape is the scheduler's job: Run() discovers devices before
+// starting its loops, and its scrape loop scrapes once immediately instead
+// of waiting for the first tick.
+func (c *SMARTMonCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, device := range c.scheduler.Devices() {
+		json, ok := c.scheduler.Get(device)
+		if !ok {
+			continue
+		}
+		smart := NewSMARTctl(json, ch)
+		smart.Collect()
+	}
+}
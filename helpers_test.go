@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tidwall/gjson"
+)
+
+// mustLoadFixture reads and parses a testdata JSON fixture.
+func mustLoadFixture(t *testing.T, path string) gjson.Result {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading fixture %s: %s", path, err)
+	}
+	if !gjson.ValidBytes(data) {
+		t.Fatalf("fixture %s is not valid JSON", path)
+	}
+	return gjson.ParseBytes(data)
+}
+
+// collectMetrics runs the full SMARTctl.Collect() pipeline over json and
+// returns every metric it emitted.
+func collectMetrics(json gjson.Result) []prometheus.Metric {
+	ch := make(chan prometheus.Metric, 256)
+	smart := NewSMARTctl(json, ch)
+	smart.Collect()
+	close(ch)
+
+	var metrics []prometheus.Metric
+	for m := range ch {
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+// fixtureCollector adapts a single smartctl JSON document to
+// prometheus.Collector, so it can be run through a real Registry.
+type fixtureCollector struct {
+	json gjson.Result
+}
+
+func (f fixtureCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (f fixtureCollector) Collect(ch chan<- prometheus.Metric) {
+	smart := NewSMARTctl(f.json, ch)
+	smart.Collect()
+}
+
+// gatherFixture runs json through a real prometheus.Registry, the same path
+// /metrics uses. Unlike collectMetrics, this catches metrics that share a
+// label set (Gather returns an error for those) rather than just collecting
+// them into a slice.
+func gatherFixture(t *testing.T, json gjson.Result) error {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(fixtureCollector{json: json}); err != nil {
+		t.Fatalf("registering collector: %s", err)
+	}
+	_, err := reg.Gather()
+	return err
+}
+
+// metricLabels returns a metric's labels as a name->value map, so assertions
+// don't depend on the internal label ordering the client library chooses.
+func metricLabels(t *testing.T, m prometheus.Metric) map[string]string {
+	t.Helper()
+	var out dto.Metric
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("writing metric: %s", err)
+	}
+	labels := make(map[string]string, len(out.Label))
+	for _, lp := range out.Label {
+		labels[lp.GetName()] = lp.GetValue()
+	}
+	return labels
+}
+
+// metricValue returns a gauge or counter metric's numeric value.
+func metricValue(t *testing.T, m prometheus.Metric) float64 {
+	t.Helper()
+	var out dto.Metric
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("writing metric: %s", err)
+	}
+	if g := out.GetGauge(); g != nil {
+		return g.GetValue()
+	}
+	if c := out.GetCounter(); c != nil {
+		return c.GetValue()
+	}
+	t.Fatalf("metric is neither a gauge nor a counter")
+	return 0
+}
+
+// findMetric returns the first metric built from desc whose labels match
+// every entry of want, or nil if none does.
+func findMetric(t *testing.T, metrics []prometheus.Metric, desc *prometheus.Desc, want map[string]string) prometheus.Metric {
+	t.Helper()
+	for _, m := range metrics {
+		if m.Desc() != desc {
+			continue
+		}
+		labels := metricLabels(t, m)
+		matches := true
+		for k, v := range want {
+			if labels[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return m
+		}
+	}
+	return nil
+}
+
+// assertValue fails the test unless metrics contains a metric built from
+// desc whose labels match every entry of labels, and the first such metric
+// has the value want. It does not check that only one metric matches.
+func assertValue(t *testing.T, metrics []prometheus.Metric, desc *prometheus.Desc, labels map[string]string, want float64) {
+	t.Helper()
+	m := findMetric(t, metrics, desc, labels)
+	if m == nil {
+		t.Fatalf("no metric found for %s matching labels %v", desc, labels)
+	}
+	if got := metricValue(t, m); got != want {
+		t.Errorf("%s%v = %v, want %v", desc, labels, got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import "log"
+
+// exporterLogger is the small leveled logger used across this package.
+// Verbose messages are only printed when verbose logging is enabled.
+type exporterLogger struct {
+	verbose bool
+}
+
+// Verbose logs a diagnostic message when verbose logging is enabled.
+func (l *exporterLogger) Verbose(format string, v ...interface{}) {
+	if l.verbose {
+		log.Printf("[verbose] "+format, v...)
+	}
+}
+
+// Error logs an error message.
+func (l *exporterLogger) Error(format string, v ...interface{}) {
+	log.Printf("[error] "+format, v...)
+}
+
+var logger = &exporterLogger{}
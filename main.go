@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/jamesnewby/smartctl_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	defaultScanInterval   = 10 * time.Minute
+	defaultScrapeInterval = 1 * time.Minute
+	defaultCacheTTL       = 5 * time.Minute
+)
+
+var (
+	smartctlPath        = flag.String("smartctl.path", "smartctl", "Path to the smartctl binary")
+	smartctlFakeJSONDir = flag.String("smartctl.fake-json-dir", "", "Read pre-captured smartctl JSON from this directory (keyed by device name) instead of running smartctl. Useful for reproducing user bug reports.")
+	smartctlStdin       = flag.Bool("smartctl.stdin", false, "Read a single smartctl JSON document from stdin instead of running smartctl")
+	maxConcurrency      = flag.Int("smartctl.max-concurrency", 4, "Maximum number of concurrent smartctl invocations")
+	scanInterval        = flag.Duration("smartctl.scan-interval", defaultScanInterval, "How often to re-run `smartctl --scan` to discover devices")
+	scrapeInterval      = flag.Duration("smartctl.scrape-interval", defaultScrapeInterval, "How often to refresh each device's smartctl output")
+	cacheTTL            = flag.Duration("smartctl.cache-ttl", defaultCacheTTL, "How long a cached smartctl result stays valid")
+	listenAddress       = flag.String("web.listen-address", ":9633", "Address to listen on for telemetry")
+	verbose             = flag.Bool("log.verbose", false, "Enable verbose logging")
+)
+
+// newRunner picks the SmartctlRunner implementation selected by flags.
+// --smartctl.fake-json-dir and --smartctl.stdin are mutually exclusive with
+// running the real smartctl binary, and are intended for tests and
+// reproducing user bug reports without access to their hardware.
+func newRunner() collector.SmartctlRunner {
+	switch {
+	case *smartctlFakeJSONDir != "":
+		return collector.FileRunner{Dir: *smartctlFakeJSONDir}
+	case *smartctlStdin:
+		return &collector.StdinRunner{}
+	default:
+		return collector.ExecRunner{Path: *smartctlPath}
+	}
+}
+
+func main() {
+	flag.Parse()
+	logger.verbose = *verbose
+
+	scheduler := collector.NewScheduler(collector.Config{
+		Runner:         newRunner(),
+		ScanInterval:   *scanInterval,
+		ScrapeInterval: *scrapeInterval,
+		CacheTTL:       *cacheTTL,
+		MaxConcurrency: *maxConcurrency,
+		Logger:         logger,
+	})
+	go scheduler.Run(context.Background())
+
+	prometheus.MustRegister(scheduler)
+	prometheus.MustRegister(NewSMARTMonCollector(scheduler))
+
+	http.Handle("/metrics", promhttp.Handler())
+	logger.Error("listening on %s: %s", *listenAddress, http.ListenAndServe(*listenAddress, nil))
+}
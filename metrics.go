@@ -221,4 +221,234 @@ var (
 		},
 		nil,
 	)
+	metricNvmeCriticalWarning = prometheus.NewDesc(
+		"smartctl_device_nvme_critical_warning",
+		"Critical warning condition decoded from the critical_warning bitfield",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+			"condition",
+		},
+		nil,
+	)
+	metricNvmeTemperature = prometheus.NewDesc(
+		"smartctl_device_nvme_temperature",
+		"Composite temperature of the NVMe controller, degrees celsius",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeTemperatureSensor = prometheus.NewDesc(
+		"smartctl_device_nvme_temperature_sensor",
+		"Temperature reported by an individual NVMe temperature sensor, degrees celsius",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+			"sensor_id",
+		},
+		nil,
+	)
+	metricNvmeAvailableSpareThreshold = prometheus.NewDesc(
+		"smartctl_device_nvme_available_spare_threshold",
+		"Threshold below which the available spare space is below the device's manufacturer-set threshold",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeDataUnitsReadBytes = prometheus.NewDesc(
+		"smartctl_device_nvme_data_units_read_bytes",
+		"Bytes read by the host, derived from data_units_read using the 512000-byte unit convention",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeDataUnitsWrittenBytes = prometheus.NewDesc(
+		"smartctl_device_nvme_data_units_written_bytes",
+		"Bytes written by the host, derived from data_units_written using the 512000-byte unit convention",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeHostReads = prometheus.NewDesc(
+		"smartctl_device_nvme_host_reads",
+		"Number of read commands completed by the host",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeHostWrites = prometheus.NewDesc(
+		"smartctl_device_nvme_host_writes",
+		"Number of write commands completed by the host",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeControllerBusyTime = prometheus.NewDesc(
+		"smartctl_device_nvme_controller_busy_minutes",
+		"Amount of time the controller was busy with I/O commands, minutes",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmePowerCycles = prometheus.NewDesc(
+		"smartctl_device_nvme_power_cycles",
+		"Number of power cycles reported in the NVMe SMART/Health information log",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmePowerOnHours = prometheus.NewDesc(
+		"smartctl_device_nvme_power_on_hours",
+		"Number of power-on hours reported in the NVMe SMART/Health information log",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeUnsafeShutdowns = prometheus.NewDesc(
+		"smartctl_device_nvme_unsafe_shutdowns",
+		"Number of unsafe shutdowns",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricNvmeNumErrLogEntries = prometheus.NewDesc(
+		"smartctl_device_nvme_num_err_log_entries",
+		"Number of firmware internal error log entries",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricDeviceSelfTestStatus = prometheus.NewDesc(
+		"smartctl_device_self_test_status",
+		"Result of a SMART self-test log entry",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+			"test_type",
+			"result",
+			"index",
+		},
+		nil,
+	)
+	metricDeviceSelfTestLifetimeHours = prometheus.NewDesc(
+		"smartctl_device_self_test_lifetime_hours",
+		"Power-on lifetime hours at which the self-test log entry was recorded",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+			"test_type",
+			"index",
+		},
+		nil,
+	)
+	metricDeviceLastSelfTestSecondsAgo = prometheus.NewDesc(
+		"smartctl_device_last_self_test_seconds_ago",
+		"Seconds since the most recent self-test log entry was recorded, derived from power_on_hours",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricScsiGrownDefectList = prometheus.NewDesc(
+		"smartctl_scsi_grown_defect_list",
+		"Number of grown defects found on the SCSI device",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
+	metricScsiErrorCounter = prometheus.NewDesc(
+		"smartctl_scsi_error_counter",
+		"SCSI error counter log",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+			"operation",
+			"error_type",
+		},
+		nil,
+	)
+	metricScsiStartStopCycleCounter = prometheus.NewDesc(
+		"smartctl_scsi_start_stop_cycle_counter",
+		"SCSI start-stop cycle counter log",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+			"counter_name",
+		},
+		nil,
+	)
+	metricScsiPercentageUsedEnduranceIndicator = prometheus.NewDesc(
+		"smartctl_scsi_percentage_used_endurance_indicator",
+		"Percentage of the SCSI device's endurance used",
+		[]string{
+			"device",
+			"model_family",
+			"model_name",
+			"serial_number",
+		},
+		nil,
+	)
 )
@@ -51,7 +51,11 @@ func (smart *SMARTctl) Collect() {
 	smart.minePowerCycleCount()
 	smart.mineDeviceStatistics()
 	smart.mineNvmeSmartHealthInformationLog()
+	smart.mineSelfTestLog()
 	smart.mineSmartStatus()
+	if smart.json.Get("device.protocol").String() == "SCSI" {
+		smart.mineScsi()
+	}
 }
 
 func (smart *SMARTctl) mineExitStatus() {
@@ -269,9 +273,13 @@ func (smart *SMARTctl) mineLongFlags(json gjson.Result, flags []string) string {
 	return strings.Join(result, ",")
 }
 
+// nvmeUnitSizeBytes is the fixed unit size smartctl uses when reporting
+// NVMe data_units_read/data_units_written (see NVMe Base Spec, SMART/Health log).
+const nvmeUnitSizeBytes = 512000
+
 func (smart *SMARTctl) mineNvmeSmartHealthInformationLog() {
 	iHealth := smart.json.Get("nvme_smart_health_information_log")
-	if (iHealth == nil) {
+	if !iHealth.Exists() {
 		return
 	}
 	smart.ch <- prometheus.MustNewConstMetric(
@@ -283,6 +291,7 @@ func (smart *SMARTctl) mineNvmeSmartHealthInformationLog() {
 		smart.device.model,
 		smart.device.serial,
 	)
+	smart.mineNvmeCriticalWarning(iHealth.Get("critical_warning").Int())
 	smart.ch <- prometheus.MustNewConstMetric(
 		metricAvailableSpare,
 		prometheus.GaugeValue,
@@ -292,6 +301,15 @@ func (smart *SMARTctl) mineNvmeSmartHealthInformationLog() {
 		smart.device.model,
 		smart.device.serial,
 	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeAvailableSpareThreshold,
+		prometheus.GaugeValue,
+		iHealth.Get("available_spare_threshold").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
 	smart.ch <- prometheus.MustNewConstMetric(
 		metricMediaErrors,
 		prometheus.GaugeValue,
@@ -302,14 +320,258 @@ func (smart *SMARTctl) mineNvmeSmartHealthInformationLog() {
 		smart.device.serial,
 	)
 	smart.ch <- prometheus.MustNewConstMetric(
-			metricPercentageUsed,
+		metricPercentageUsed,
+		prometheus.GaugeValue,
+		iHealth.Get("percentage_used").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeTemperature,
+		prometheus.GaugeValue,
+		iHealth.Get("temperature").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeDataUnitsReadBytes,
+		prometheus.CounterValue,
+		iHealth.Get("data_units_read").Float()*nvmeUnitSizeBytes,
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeDataUnitsWrittenBytes,
+		prometheus.CounterValue,
+		iHealth.Get("data_units_written").Float()*nvmeUnitSizeBytes,
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeHostReads,
+		prometheus.CounterValue,
+		iHealth.Get("host_reads").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeHostWrites,
+		prometheus.CounterValue,
+		iHealth.Get("host_writes").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeControllerBusyTime,
+		prometheus.CounterValue,
+		iHealth.Get("controller_busy_time").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmePowerCycles,
+		prometheus.CounterValue,
+		iHealth.Get("power_cycles").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmePowerOnHours,
+		prometheus.CounterValue,
+		iHealth.Get("power_on_hours").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeUnsafeShutdowns,
+		prometheus.CounterValue,
+		iHealth.Get("unsafe_shutdowns").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricNvmeNumErrLogEntries,
+		prometheus.CounterValue,
+		iHealth.Get("num_err_log_entries").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	for key, value := range iHealth.Map() {
+		if !strings.HasPrefix(key, "temperature_sensor_") {
+			continue
+		}
+		smart.ch <- prometheus.MustNewConstMetric(
+			metricNvmeTemperatureSensor,
+			prometheus.GaugeValue,
+			value.Float(),
+			smart.device.device,
+			smart.device.family,
+			smart.device.model,
+			smart.device.serial,
+			strings.TrimPrefix(key, "temperature_sensor_"),
+		)
+	}
+}
+
+// nvmeCriticalWarningBits maps the bit position of the NVMe critical_warning
+// field (NVMe Base Spec, SMART/Health log) to the condition it reports.
+var nvmeCriticalWarningBits = []string{
+	"available_spare",
+	"temperature",
+	"degraded",
+	"read_only",
+	"volatile_memory_backup",
+}
+
+func (smart *SMARTctl) mineNvmeCriticalWarning(bitfield int64) {
+	for bit, condition := range nvmeCriticalWarningBits {
+		value := 0.0
+		if bitfield&(1<<uint(bit)) != 0 {
+			value = 1
+		}
+		smart.ch <- prometheus.MustNewConstMetric(
+			metricNvmeCriticalWarning,
 			prometheus.GaugeValue,
-			iHealth.Get("percentage_used").Float(),
+			value,
 			smart.device.device,
 			smart.device.family,
 			smart.device.model,
 			smart.device.serial,
+			condition,
 		)
+	}
+}
+
+// selfTestEntry is a normalized row from either the ATA or NVMe self-test log.
+type selfTestEntry struct {
+	testType      string
+	result        string
+	resultValue   float64
+	lifetimeHours float64
+	source        string // "ata" or "nvme", selects how currentLifetimeHours is read
+	index         string // position within its own log table, so repeated entries of the same test_type don't collide
+}
+
+// currentLifetimeHours returns the device's current power-on hours, read
+// from whichever location the entry's protocol reports it under.
+func (smart *SMARTctl) currentLifetimeHours(source string) float64 {
+	if source == "nvme" {
+		return smart.json.Get("nvme_smart_health_information_log.power_on_hours").Float()
+	}
+	return GetFloatIfExists(smart.json.Get("power_on_time"), "hours", 0)
+}
+
+func (smart *SMARTctl) mineSelfTestLog() {
+	entries := smart.mineAtaSelfTestEntries()
+	entries = append(entries, smart.mineNvmeSelfTestEntries()...)
+	for _, entry := range entries {
+		smart.ch <- prometheus.MustNewConstMetric(
+			metricDeviceSelfTestStatus,
+			prometheus.GaugeValue,
+			entry.resultValue,
+			smart.device.device,
+			smart.device.family,
+			smart.device.model,
+			smart.device.serial,
+			entry.testType,
+			entry.result,
+			entry.index,
+		)
+		smart.ch <- prometheus.MustNewConstMetric(
+			metricDeviceSelfTestLifetimeHours,
+			prometheus.GaugeValue,
+			entry.lifetimeHours,
+			smart.device.device,
+			smart.device.family,
+			smart.device.model,
+			smart.device.serial,
+			entry.testType,
+			entry.index,
+		)
+	}
+	if len(entries) == 0 {
+		return
+	}
+	currentHours := smart.currentLifetimeHours(entries[0].source)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricDeviceLastSelfTestSecondsAgo,
+		prometheus.GaugeValue,
+		(currentHours-entries[0].lifetimeHours)*60*60,
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+}
+
+// normalizeSelfTestType maps the free-form test description smartctl reports
+// (e.g. "Short offline", "Extended offline") onto the canonical test types.
+func normalizeSelfTestType(raw string) string {
+	lower := strings.ToLower(raw)
+	switch {
+	case strings.Contains(lower, "conveyance"):
+		return "conveyance"
+	case strings.Contains(lower, "selective"):
+		return "selective"
+	case strings.Contains(lower, "extended"), strings.Contains(lower, "long"):
+		return "long"
+	case strings.Contains(lower, "short"):
+		return "short"
+	default:
+		return lower
+	}
+}
+
+func (smart *SMARTctl) mineAtaSelfTestEntries() []selfTestEntry {
+	var entries []selfTestEntry
+	for i, entry := range smart.json.Get("ata_smart_self_test_log.standard.table").Array() {
+		entries = append(entries, selfTestEntry{
+			testType:      normalizeSelfTestType(entry.Get("type.string").String()),
+			result:        strings.TrimSpace(entry.Get("status.string").String()),
+			resultValue:   entry.Get("status.value").Float(),
+			lifetimeHours: entry.Get("lifetime_hours").Float(),
+			source:        "ata",
+			index:         fmt.Sprintf("%d", i),
+		})
+	}
+	return entries
+}
+
+func (smart *SMARTctl) mineNvmeSelfTestEntries() []selfTestEntry {
+	var entries []selfTestEntry
+	for i, entry := range smart.json.Get("nvme_self_test_log.table").Array() {
+		entries = append(entries, selfTestEntry{
+			testType:      normalizeSelfTestType(entry.Get("self_test_code.string").String()),
+			result:        strings.TrimSpace(entry.Get("self_test_result.string").String()),
+			resultValue:   entry.Get("self_test_result.value").Float(),
+			lifetimeHours: entry.Get("power_on_hours").Float(),
+			source:        "nvme",
+			index:         fmt.Sprintf("%d", i),
+		})
+	}
+	return entries
 }
 
 func (smart *SMARTctl) mineSmartStatus() {
@@ -324,3 +586,69 @@ func (smart *SMARTctl) mineSmartStatus() {
 		smart.device.serial,
 	)
 }
+
+// scsiErrorCounterFields are the sub-fields of each scsi_error_counter_log
+// operation, keyed by the error_type label value they are reported under.
+var scsiErrorCounterFields = []string{
+	"errors_corrected_by_eccfast",
+	"errors_corrected_by_eccdelayed",
+	"errors_corrected_by_rereads_rewrites",
+	"total_errors_corrected",
+	"correction_algorithm_invocations",
+	"gigabytes_processed",
+	"total_uncorrected_errors",
+}
+
+func (smart *SMARTctl) mineScsi() {
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricScsiGrownDefectList,
+		prometheus.GaugeValue,
+		smart.json.Get("scsi_grown_defect_list").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	smart.ch <- prometheus.MustNewConstMetric(
+		metricScsiPercentageUsedEnduranceIndicator,
+		prometheus.GaugeValue,
+		smart.json.Get("scsi_percentage_used_endurance_indicator").Float(),
+		smart.device.device,
+		smart.device.family,
+		smart.device.model,
+		smart.device.serial,
+	)
+	errorCounterLog := smart.json.Get("scsi_error_counter_log")
+	for _, operation := range []string{"read", "write", "verify"} {
+		counters := errorCounterLog.Get(operation)
+		if !counters.Exists() {
+			continue
+		}
+		for _, errorType := range scsiErrorCounterFields {
+			smart.ch <- prometheus.MustNewConstMetric(
+				metricScsiErrorCounter,
+				prometheus.GaugeValue,
+				counters.Get(errorType).Float(),
+				smart.device.device,
+				smart.device.family,
+				smart.device.model,
+				smart.device.serial,
+				operation,
+				errorType,
+			)
+		}
+	}
+	smart.json.Get("scsi_start_stop_cycle_counter").ForEach(func(key, value gjson.Result) bool {
+		smart.ch <- prometheus.MustNewConstMetric(
+			metricScsiStartStopCycleCounter,
+			prometheus.GaugeValue,
+			value.Float(),
+			smart.device.device,
+			smart.device.family,
+			smart.device.model,
+			smart.device.serial,
+			key.String(),
+		)
+		return true
+	})
+}
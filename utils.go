@@ -0,0 +1,21 @@
+package main
+
+import "github.com/tidwall/gjson"
+
+// GetFloatIfExists returns json.Get(path).Float(), or def if that path is
+// not present in json.
+func GetFloatIfExists(json gjson.Result, path string, def float64) float64 {
+	if result := json.Get(path); result.Exists() {
+		return result.Float()
+	}
+	return def
+}
+
+// GetStringIfExists returns json.Get(path).String(), or def if that path is
+// not present in json.
+func GetStringIfExists(json gjson.Result, path string, def string) string {
+	if result := json.Get(path); result.Exists() {
+		return result.String()
+	}
+	return def
+}